@@ -18,10 +18,16 @@ import (
 	_ "embed"
 	"encoding/json"
 	"flag"
+	"fmt"
 	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
+	bufvalidate "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/envoyproxy/protoc-gen-validate/validate"
 	"github.com/mark3labs/mcp-go/mcp"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/protobuf/compiler/protogen"
@@ -32,37 +38,209 @@ import (
 
 const (
 	generatedFilenameExtension = ".pb.mcp.go"
+	openAPIFilenameExtension   = ".openapi.json"
+
+	// dispatchGRPC invokes the generated gRPC client, the historical (and still
+	// default) behavior.
+	dispatchGRPC = "grpc"
+	// dispatchHTTP performs a plain HTTP call derived from the method's
+	// google.api.http annotation instead of going through gRPC.
+	dispatchHTTP = "http"
+
+	// streamingSkip drops streaming methods entirely, the historical (and
+	// still default) behavior.
+	streamingSkip = "skip"
+	// streamingCollect buffers a server-streaming response (or accepts a
+	// JSON array for client-streaming) into/from a single tool call.
+	streamingCollect = "collect"
+	// streamingSubscribe exposes a server-streaming method as a tool that
+	// starts the stream and pushes one "<tool name>/message" notification per
+	// streamed message to every connected client instead of buffering them.
+	// No MCP resource is registered.
+	streamingSubscribe = "subscribe"
 )
 
 func main() {
 	var flagSet flag.FlagSet
+	dispatch := flagSet.String("dispatch", dispatchGRPC,
+		`how generated tools invoke the RPC: "grpc" (default) calls the gRPC client, `+
+			`"http" performs a REST call derived from the method's google.api.http option`)
+	httpBaseURL := flagSet.String("http_base_url", "",
+		"base URL prepended to the path template when --dispatch=http (e.g. https://api.example.com)")
+	httpHeaders := flagSet.String("http_headers", "",
+		`comma-separated Key:Value pairs sent as headers on every HTTP-dispatched call, e.g. "Authorization:Bearer xyz,X-Api-Version:2"; `+
+			`prefix a pair with "Service_Method/" to scope it to one method instead, e.g. "LibraryService_GetBook/X-Api-Version:3"`)
+	httpBaseURLOverrides := flagSet.String("http_base_url_overrides", "",
+		`comma-separated Service_Method=URL pairs overriding --http_base_url for individual methods, e.g. "LibraryService_GetBook=https://legacy.example.com"`)
+	streaming := flagSet.String("streaming", streamingSkip,
+		`how to handle streaming RPCs: "skip" (default) ignores them, "collect" buffers a server stream into one `+
+			`response (or sends a JSON array for a client stream), "subscribe" starts a server stream and pushes `+
+			`one notification per message to every connected client`)
+	streamingMaxItems := flagSet.Int("streaming_max_items", 100,
+		"max number of messages buffered for a server-streaming tool when --streaming=collect")
+	streamingTimeout := flagSet.String("streaming_timeout", "30s",
+		"max time a server-streaming tool waits to fill its buffer when --streaming=collect, as a Go duration string")
+	useProtoNames := flagSet.Bool("use_proto_names", false,
+		"use the raw proto field name instead of its JSON name (FieldDescriptor.JSONName(), respecting explicit json_name overrides) for schema property names")
+	includeDescriptions := flagSet.Bool("include_descriptions", true,
+		"lift field leading and trailing comments, and message and enum-value leading comments, into JSON Schema \"description\"/\"enumDescriptions\"; "+
+			"disable to shrink generated schemas in size-sensitive deployments")
+	emitOpenAPI := flagSet.Bool("emit_openapi", false,
+		"also write a companion <prefix>.openapi.json document describing every method as an OpenAPI 3.1 operation")
 
 	protogen.Options{
 		ParamFunc: flagSet.Set,
 	}.Run(func(gen *protogen.Plugin) error {
+		headers, headerOverrides := parseHTTPHeaders(*httpHeaders)
+		opts := dispatchOptions{
+			mode:             *dispatch,
+			baseURL:          *httpBaseURL,
+			headers:          headers,
+			baseURLOverrides: parseHTTPBaseURLOverrides(*httpBaseURLOverrides),
+			headerOverrides:  headerOverrides,
+		}
+		if opts.mode != dispatchGRPC && opts.mode != dispatchHTTP {
+			return fmt.Errorf("protoc-gen-go-mcp: unknown --dispatch value %q, want %q or %q", opts.mode, dispatchGRPC, dispatchHTTP)
+		}
+		streamOpts := streamingOptions{
+			mode:     *streaming,
+			maxItems: *streamingMaxItems,
+			timeout:  *streamingTimeout,
+		}
+		if streamOpts.mode != streamingSkip && streamOpts.mode != streamingCollect && streamOpts.mode != streamingSubscribe {
+			return fmt.Errorf("protoc-gen-go-mcp: unknown --streaming value %q, want %q, %q, or %q",
+				streamOpts.mode, streamingSkip, streamingCollect, streamingSubscribe)
+		}
 		for _, f := range gen.Files {
 			if !f.Generate {
 				continue
 			}
-			newFileGenerator(f, gen).Generate()
+			newFileGenerator(f, gen, opts, streamOpts, *useProtoNames, *includeDescriptions, *emitOpenAPI).Generate()
 		}
 		return nil
 
 	})
 }
 
+// dispatchOptions carries the --dispatch family of flags down to each
+// fileGenerator so every file in the run agrees on how tools invoke the RPC.
+type dispatchOptions struct {
+	mode    string
+	baseURL string
+	headers map[string]string
+	// baseURLOverrides maps a "Service_Method" name (see methodName in
+	// (*fileGenerator).Generate) to a base URL that replaces baseURL for that
+	// method only.
+	baseURLOverrides map[string]string
+	// headerOverrides maps a "Service_Method" name to headers that are layered
+	// on top of headers for that method only, see mergeHTTPHeaders.
+	headerOverrides map[string]map[string]string
+}
+
+// streamingOptions carries the --streaming family of flags down to each
+// fileGenerator so every file in the run agrees on how streaming methods are
+// exposed.
+type streamingOptions struct {
+	mode     string
+	maxItems int
+	timeout  string
+}
+
+// parseHTTPHeaders parses the --http_headers flag into a map of headers sent
+// on every HTTP-dispatched call, plus a per-method map of headers that only
+// apply to one "Service_Method" (a pair prefixed "Service_Method/Key:Value").
+func parseHTTPHeaders(raw string) (map[string]string, map[string]map[string]string) {
+	if raw == "" {
+		return nil, nil
+	}
+	headers := map[string]string{}
+	overrides := map[string]map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		// A "/" only scopes the pair when it comes before the ":", so a value
+		// such as "Accept:application/json" stays a global header.
+		if method, rest, ok := strings.Cut(pair, "/"); ok && !strings.Contains(method, ":") {
+			k, v, ok := strings.Cut(rest, ":")
+			if !ok {
+				continue
+			}
+			if overrides[method] == nil {
+				overrides[method] = map[string]string{}
+			}
+			overrides[method][strings.TrimSpace(k)] = strings.TrimSpace(v)
+			continue
+		}
+		k, v, ok := strings.Cut(pair, ":")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	if len(overrides) == 0 {
+		overrides = nil
+	}
+	return headers, overrides
+}
+
+// parseHTTPBaseURLOverrides parses the --http_base_url_overrides flag into a
+// map of "Service_Method" names to the base URL to use for that method only.
+func parseHTTPBaseURLOverrides(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+	overrides := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		method, url, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		overrides[strings.TrimSpace(method)] = strings.TrimSpace(url)
+	}
+	return overrides
+}
+
+// mergeHTTPHeaders layers method-specific headers on top of the global
+// defaults, without mutating either input map.
+func mergeHTTPHeaders(global map[string]string, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return global
+	}
+	merged := make(map[string]string, len(global)+len(overrides))
+	for k, v := range global {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
 type fileGenerator struct {
 	f   *protogen.File
 	gen *protogen.Plugin
 
 	allConsts map[string]struct{}
 	gf        *protogen.GeneratedFile
+
+	dispatch  dispatchOptions
+	streaming streamingOptions
+	// useProtoNames makes schema property names follow the raw proto field
+	// name instead of FieldDescriptor.JSONName().
+	useProtoNames bool
+	// includeDescriptions controls whether comments are lifted into "description"/"enumDescriptions".
+	includeDescriptions bool
+	// emitOpenAPI writes a companion <prefix>.openapi.json document alongside the .pb.mcp.go file.
+	emitOpenAPI bool
 }
 
-func newFileGenerator(f *protogen.File, gen *protogen.Plugin) *fileGenerator {
+func newFileGenerator(f *protogen.File, gen *protogen.Plugin, dispatch dispatchOptions, streaming streamingOptions, useProtoNames, includeDescriptions, emitOpenAPI bool) *fileGenerator {
 	gen.SupportedFeatures |= uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 
-	return &fileGenerator{f: f, gen: gen}
+	return &fileGenerator{
+		f: f, gen: gen,
+		dispatch: dispatch, streaming: streaming,
+		useProtoNames: useProtoNames, includeDescriptions: includeDescriptions,
+		emitOpenAPI: emitOpenAPI,
+	}
 }
 
 //go:embed gen.tmpl
@@ -74,12 +252,107 @@ type tplParams struct {
 	GoPackage   string
 	Tools       map[string]mcp.Tool
 	Services    map[string]map[string]Tool
+	// Dispatch is "grpc" or "http"; see dispatchGRPC/dispatchHTTP.
+	Dispatch string
+	// Streaming is "skip", "collect", or "subscribe"; see the streaming* constants.
+	Streaming string
+	// NeedsCollectHelpers is true when at least one tool in this file is a
+	// --dispatch=grpc, --streaming=collect, purely server-streaming (non-bidi) method, the
+	// only shape whose generated handler needs "io" (io.EOF) and "time"
+	// (the collect timeout). Computed once so gen.tmpl can gate those
+	// imports instead of importing them unconditionally.
+	NeedsCollectHelpers bool
+	// FileSuffix is a Go-identifier-safe token derived from this proto
+	// file's base name, appended to every dispatch helper gen.tmpl emits
+	// (Tools, bidiSessions, decodeToolArguments, ...). Those helpers aren't
+	// tied to a proto message or service name the way everything else in
+	// this file is, so without a per-file suffix two .proto files that
+	// share a Go package would emit the same unexported identifiers twice.
+	FileSuffix string
 }
 
 type Tool struct {
 	RequestType  string
 	ResponseType string
 	MCPTool      mcp.Tool
+
+	// HTTP is non-nil when the method carries a google.api.http option. Only
+	// under --dispatch=http does the tool use it to dispatch over REST
+	// instead of gRPC.
+	HTTP *HTTPBinding
+
+	// Streaming is non-nil for a client-, server-, or bidi-streaming method
+	// generated under --streaming=collect or --streaming=subscribe.
+	Streaming *StreamingBinding
+}
+
+// StreamingBinding describes how a streaming method is surfaced as one or
+// more MCP tools/resources.
+type StreamingBinding struct {
+	Mode            string // streamingCollect or streamingSubscribe
+	ClientStreaming bool
+	ServerStreaming bool
+	// MaxItems and Timeout bound how long a "collect" server-streaming tool
+	// buffers messages before returning what it has.
+	MaxItems int
+	Timeout  string
+	// Role is "" for a unary-shaped tool, or "send"/"recv" for the two
+	// tools generated in place of a single bidi-streaming method.
+	Role string
+}
+
+// HTTPBinding describes how to turn a tool call into a plain HTTP request,
+// derived from a method's google.api.http annotation (the same annotation
+// grpc-gateway uses for transcoding).
+type HTTPBinding struct {
+	Verb         string // GET, POST, PUT, PATCH, DELETE, or a custom verb
+	PathTemplate string // raw path template, e.g. "/v1/{name=shelves/*}/books"
+	PathParams   []string
+	Body         string // "*" for the whole request, a field name, or "" for none
+	BaseURL      string
+	Headers      map[string]string
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([a-zA-Z0-9_.]+)(?:=[^}]*)?\}`)
+
+// pathParams returns the field names substituted into a google.api.http path
+// template, e.g. "/v1/{name=shelves/*}/books/{book_id}" -> ["name", "book_id"].
+func pathParams(pathTemplate string) []string {
+	var params []string
+	for _, m := range pathParamPattern.FindAllStringSubmatch(pathTemplate, -1) {
+		params = append(params, m[1])
+	}
+	return params
+}
+
+// httpRule extracts the primary google.api.http binding for a method, if any.
+// Additional bindings (HttpRule.AdditionalBindings) are not yet supported;
+// only the first pattern is used.
+func httpRule(meth *protogen.Method) (verb, path, body string, ok bool) {
+	opts := meth.Desc.Options()
+	if !proto.HasExtension(opts, annotations.E_Http) {
+		return "", "", "", false
+	}
+	rule, ok := proto.GetExtension(opts, annotations.E_Http).(*annotations.HttpRule)
+	if !ok || rule == nil {
+		return "", "", "", false
+	}
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET", pattern.Get, rule.GetBody(), true
+	case *annotations.HttpRule_Put:
+		return "PUT", pattern.Put, rule.GetBody(), true
+	case *annotations.HttpRule_Post:
+		return "POST", pattern.Post, rule.GetBody(), true
+	case *annotations.HttpRule_Delete:
+		return "DELETE", pattern.Delete, rule.GetBody(), true
+	case *annotations.HttpRule_Patch:
+		return "PATCH", pattern.Patch, rule.GetBody(), true
+	case *annotations.HttpRule_Custom:
+		return pattern.Custom.GetKind(), pattern.Custom.GetPath(), rule.GetBody(), true
+	default:
+		return "", "", "", false
+	}
 }
 
 func kindToType(kind protoreflect.Kind) string {
@@ -105,29 +378,346 @@ func kindToType(kind protoreflect.Kind) string {
 	}
 }
 
-func isFieldRequired(fd protoreflect.FieldDescriptor) bool {
-	if proto.HasExtension(fd.Options(), annotations.E_FieldBehavior) {
-		behaviors := proto.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
-		for _, behavior := range behaviors {
-			if behavior == annotations.FieldBehavior_REQUIRED {
-				return true
-			}
+// fieldBehaviors returns the google.api.field_behavior values annotated on fd, if any.
+func fieldBehaviors(fd protoreflect.FieldDescriptor) []annotations.FieldBehavior {
+	if !proto.HasExtension(fd.Options(), annotations.E_FieldBehavior) {
+		return nil
+	}
+	return proto.GetExtension(fd.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+}
+
+func hasFieldBehavior(fd protoreflect.FieldDescriptor, want annotations.FieldBehavior) bool {
+	for _, b := range fieldBehaviors(fd) {
+		if b == want {
+			return true
+		}
+	}
+	return false
+}
+
+// applyFieldBehaviorAnnotations surfaces IMMUTABLE and INPUT_ONLY as schema
+// hints, since JSON Schema has no native equivalent of either. OUTPUT_ONLY is
+// handled separately: those fields are dropped from the input schema entirely.
+func applyFieldBehaviorAnnotations(fd protoreflect.FieldDescriptor, schema map[string]any) {
+	switch {
+	case hasFieldBehavior(fd, annotations.FieldBehavior_IMMUTABLE):
+		note := "This field is immutable: it can only be set when the resource is created."
+		if existing, _ := schema["$comment"].(string); existing != "" {
+			note = existing + " " + note
+		}
+		schema["$comment"] = note
+	case hasFieldBehavior(fd, annotations.FieldBehavior_INPUT_ONLY):
+		schema["writeOnly"] = true
+	}
+}
+
+// isFieldRequired reports whether fd must be set, via google.api.field_behavior
+// REQUIRED or a PGV/buf.validate required rule. disabled short-circuits the
+// latter two, matching every other PGV/buf.validate projection in this file:
+// a validate.disabled/(buf.validate.message).disabled message has no active
+// validation rules to derive "required" from.
+func isFieldRequired(fd protoreflect.FieldDescriptor, disabled bool) bool {
+	if hasFieldBehavior(fd, annotations.FieldBehavior_REQUIRED) {
+		return true
+	}
+	if disabled {
+		return false
+	}
+	if pgv := pgvRules(fd); pgv != nil && pgv.GetMessage().GetRequired() {
+		return true
+	}
+	if bv := bufValidateConstraints(fd); bv != nil && bv.GetRequired() {
+		return true
+	}
+	return false
+}
+
+// pgvRules returns the protoc-gen-validate field rules for fd, or nil.
+func pgvRules(fd protoreflect.FieldDescriptor) *validate.FieldRules {
+	if !proto.HasExtension(fd.Options(), validate.E_Rules) {
+		return nil
+	}
+	rules, _ := proto.GetExtension(fd.Options(), validate.E_Rules).(*validate.FieldRules)
+	return rules
+}
+
+// bufValidateConstraints returns the buf.validate field constraints for fd, or nil.
+func bufValidateConstraints(fd protoreflect.FieldDescriptor) *bufvalidate.FieldConstraints {
+	if !proto.HasExtension(fd.Options(), bufvalidate.E_Field) {
+		return nil
+	}
+	constraints, _ := proto.GetExtension(fd.Options(), bufvalidate.E_Field).(*bufvalidate.FieldConstraints)
+	return constraints
+}
+
+// messageValidationDisabled reports whether validation has been turned off
+// for md via `option (validate.disabled)` or `option (buf.validate.message).disabled`.
+func messageValidationDisabled(md protoreflect.MessageDescriptor) bool {
+	opts := md.Options()
+	if proto.HasExtension(opts, validate.E_Disabled) && proto.GetExtension(opts, validate.E_Disabled).(bool) {
+		return true
+	}
+	if proto.HasExtension(opts, bufvalidate.E_Message) {
+		if mc, ok := proto.GetExtension(opts, bufvalidate.E_Message).(*bufvalidate.MessageConstraints); ok && mc.GetDisabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// messageCELExpressions collects CEL rule expressions declared on md (via
+// buf.validate; PGV has no message-level CEL) so callers can surface them to
+// LLMs as a $comment, since JSON Schema has no way to express them directly.
+func messageCELExpressions(md protoreflect.MessageDescriptor) []string {
+	opts := md.Options()
+	if !proto.HasExtension(opts, bufvalidate.E_Message) {
+		return nil
+	}
+	mc, ok := proto.GetExtension(opts, bufvalidate.E_Message).(*bufvalidate.MessageConstraints)
+	if !ok {
+		return nil
+	}
+	var exprs []string
+	for _, c := range mc.GetCel() {
+		exprs = append(exprs, c.GetExpression())
+	}
+	return exprs
+}
+
+// applyNumericRule projects the common gt/gte/lt/lte/const/in fields shared
+// by every protoc-gen-validate and buf.validate numeric rule message onto a
+// JSON Schema fragment.
+func applyNumericRule[T int32 | int64 | uint32 | uint64 | float32 | float64](schema map[string]any, gt, gte, lt, lte, constVal *T, in []T) {
+	if gt != nil {
+		schema["exclusiveMinimum"] = *gt
+	}
+	if gte != nil {
+		schema["minimum"] = *gte
+	}
+	if lt != nil {
+		schema["exclusiveMaximum"] = *lt
+	}
+	if lte != nil {
+		schema["maximum"] = *lte
+	}
+	if constVal != nil {
+		schema["const"] = *constVal
+	}
+	if len(in) > 0 {
+		schema["enum"] = in
+	}
+}
+
+// applyPGVFieldConstraints translates protoc-gen-validate `validate.rules`
+// for a scalar field into the equivalent JSON Schema keywords.
+func applyPGVFieldConstraints(rules *validate.FieldRules, schema map[string]any) {
+	if rules == nil {
+		return
+	}
+	switch r := rules.GetType().(type) {
+	case *validate.FieldRules_String_:
+		sr := r.String_
+		if sr.MinLen != nil {
+			schema["minLength"] = sr.GetMinLen()
+		}
+		if sr.MaxLen != nil {
+			schema["maxLength"] = sr.GetMaxLen()
+		}
+		if sr.Pattern != nil {
+			schema["pattern"] = sr.GetPattern()
+		}
+		if sr.Const != nil {
+			schema["const"] = sr.GetConst()
+		}
+		if len(sr.In) > 0 {
+			schema["enum"] = sr.In
+		}
+	case *validate.FieldRules_Int32:
+		applyNumericRule(schema, r.Int32.Gt, r.Int32.Gte, r.Int32.Lt, r.Int32.Lte, r.Int32.Const, r.Int32.In)
+	case *validate.FieldRules_Sint32:
+		applyNumericRule(schema, r.Sint32.Gt, r.Sint32.Gte, r.Sint32.Lt, r.Sint32.Lte, r.Sint32.Const, r.Sint32.In)
+	case *validate.FieldRules_Sfixed32:
+		applyNumericRule(schema, r.Sfixed32.Gt, r.Sfixed32.Gte, r.Sfixed32.Lt, r.Sfixed32.Lte, r.Sfixed32.Const, r.Sfixed32.In)
+	case *validate.FieldRules_Uint32:
+		applyNumericRule(schema, r.Uint32.Gt, r.Uint32.Gte, r.Uint32.Lt, r.Uint32.Lte, r.Uint32.Const, r.Uint32.In)
+	case *validate.FieldRules_Fixed32:
+		applyNumericRule(schema, r.Fixed32.Gt, r.Fixed32.Gte, r.Fixed32.Lt, r.Fixed32.Lte, r.Fixed32.Const, r.Fixed32.In)
+	case *validate.FieldRules_Int64:
+		applyNumericRule(schema, r.Int64.Gt, r.Int64.Gte, r.Int64.Lt, r.Int64.Lte, r.Int64.Const, r.Int64.In)
+	case *validate.FieldRules_Sint64:
+		applyNumericRule(schema, r.Sint64.Gt, r.Sint64.Gte, r.Sint64.Lt, r.Sint64.Lte, r.Sint64.Const, r.Sint64.In)
+	case *validate.FieldRules_Sfixed64:
+		applyNumericRule(schema, r.Sfixed64.Gt, r.Sfixed64.Gte, r.Sfixed64.Lt, r.Sfixed64.Lte, r.Sfixed64.Const, r.Sfixed64.In)
+	case *validate.FieldRules_Uint64:
+		applyNumericRule(schema, r.Uint64.Gt, r.Uint64.Gte, r.Uint64.Lt, r.Uint64.Lte, r.Uint64.Const, r.Uint64.In)
+	case *validate.FieldRules_Fixed64:
+		applyNumericRule(schema, r.Fixed64.Gt, r.Fixed64.Gte, r.Fixed64.Lt, r.Fixed64.Lte, r.Fixed64.Const, r.Fixed64.In)
+	case *validate.FieldRules_Float:
+		applyNumericRule(schema, r.Float.Gt, r.Float.Gte, r.Float.Lt, r.Float.Lte, r.Float.Const, r.Float.In)
+	case *validate.FieldRules_Double:
+		applyNumericRule(schema, r.Double.Gt, r.Double.Gte, r.Double.Lt, r.Double.Lte, r.Double.Const, r.Double.In)
+	}
+}
+
+// applyBufValidateFieldConstraints translates `buf.validate.field` constraints
+// for a scalar field into the equivalent JSON Schema keywords.
+func applyBufValidateFieldConstraints(rules *bufvalidate.FieldConstraints, schema map[string]any) {
+	if rules == nil {
+		return
+	}
+	switch r := rules.GetType().(type) {
+	case *bufvalidate.FieldConstraints_String_:
+		sr := r.String_
+		if sr.MinLen != nil {
+			schema["minLength"] = sr.GetMinLen()
+		}
+		if sr.MaxLen != nil {
+			schema["maxLength"] = sr.GetMaxLen()
 		}
+		if sr.Pattern != nil {
+			schema["pattern"] = sr.GetPattern()
+		}
+		if sr.Const != nil {
+			schema["const"] = sr.GetConst()
+		}
+		if len(sr.In) > 0 {
+			schema["enum"] = sr.In
+		}
+	case *bufvalidate.FieldConstraints_Int32:
+		applyNumericRule(schema, r.Int32.Gt, r.Int32.Gte, r.Int32.Lt, r.Int32.Lte, r.Int32.Const, r.Int32.In)
+	case *bufvalidate.FieldConstraints_Int64:
+		applyNumericRule(schema, r.Int64.Gt, r.Int64.Gte, r.Int64.Lt, r.Int64.Lte, r.Int64.Const, r.Int64.In)
+	case *bufvalidate.FieldConstraints_Uint32:
+		applyNumericRule(schema, r.Uint32.Gt, r.Uint32.Gte, r.Uint32.Lt, r.Uint32.Lte, r.Uint32.Const, r.Uint32.In)
+	case *bufvalidate.FieldConstraints_Uint64:
+		applyNumericRule(schema, r.Uint64.Gt, r.Uint64.Gte, r.Uint64.Lt, r.Uint64.Lte, r.Uint64.Const, r.Uint64.In)
+	case *bufvalidate.FieldConstraints_Float:
+		applyNumericRule(schema, r.Float.Gt, r.Float.Gte, r.Float.Lt, r.Float.Lte, r.Float.Const, r.Float.In)
+	case *bufvalidate.FieldConstraints_Double:
+		applyNumericRule(schema, r.Double.Gt, r.Double.Gte, r.Double.Lt, r.Double.Lte, r.Double.Const, r.Double.In)
+	}
+	if len(rules.GetCel()) > 0 {
+		var exprs []string
+		for _, c := range rules.GetCel() {
+			exprs = append(exprs, c.GetExpression())
+		}
+		schema["$comment"] = "CEL rules: " + strings.Join(exprs, "; ")
+	}
+}
+
+// pgvFieldIgnoresEmpty reports whether rules' active rule variant carries
+// `ignore_empty`, meaning protoc-gen-validate never enforces the rule against
+// a zero-value field. JSON Schema has no "only when set" form of a
+// constraint, so ignore_empty suppresses emitting it entirely rather than
+// producing a constraint PGV wouldn't actually apply.
+func pgvFieldIgnoresEmpty(rules *validate.FieldRules) bool {
+	if rules == nil {
+		return false
+	}
+	switch r := rules.GetType().(type) {
+	case *validate.FieldRules_String_:
+		return r.String_.GetIgnoreEmpty()
+	case *validate.FieldRules_Int32:
+		return r.Int32.GetIgnoreEmpty()
+	case *validate.FieldRules_Sint32:
+		return r.Sint32.GetIgnoreEmpty()
+	case *validate.FieldRules_Sfixed32:
+		return r.Sfixed32.GetIgnoreEmpty()
+	case *validate.FieldRules_Uint32:
+		return r.Uint32.GetIgnoreEmpty()
+	case *validate.FieldRules_Fixed32:
+		return r.Fixed32.GetIgnoreEmpty()
+	case *validate.FieldRules_Int64:
+		return r.Int64.GetIgnoreEmpty()
+	case *validate.FieldRules_Sint64:
+		return r.Sint64.GetIgnoreEmpty()
+	case *validate.FieldRules_Sfixed64:
+		return r.Sfixed64.GetIgnoreEmpty()
+	case *validate.FieldRules_Uint64:
+		return r.Uint64.GetIgnoreEmpty()
+	case *validate.FieldRules_Fixed64:
+		return r.Fixed64.GetIgnoreEmpty()
+	case *validate.FieldRules_Float:
+		return r.Float.GetIgnoreEmpty()
+	case *validate.FieldRules_Double:
+		return r.Double.GetIgnoreEmpty()
 	}
 	return false
 }
 
-func messageSchema(md protoreflect.MessageDescriptor) map[string]any {
+// bufValidateIgnored reports whether rules' top-level `ignore` option removes
+// this field from validation (IGNORE_IF_ZERO_VALUE or IGNORE_ALWAYS). As with
+// pgvFieldIgnoresEmpty, JSON Schema can't express a conditional constraint,
+// so either value means not emitting one here.
+func bufValidateIgnored(rules *bufvalidate.FieldConstraints) bool {
+	if rules == nil {
+		return false
+	}
+	switch rules.GetIgnore() {
+	case bufvalidate.Ignore_IGNORE_IF_ZERO_VALUE, bufvalidate.Ignore_IGNORE_ALWAYS:
+		return true
+	default:
+		return false
+	}
+}
+
+// applyRepeatedConstraints translates the `repeated` rules shared by both
+// validation systems (min_items/max_items/unique) onto an array schema.
+func applyRepeatedConstraints(fd protoreflect.FieldDescriptor, schema map[string]any) {
+	if pgv := pgvRules(fd); pgv != nil {
+		if rr := pgv.GetRepeated(); rr != nil {
+			if rr.MinItems != nil {
+				schema["minItems"] = rr.GetMinItems()
+			}
+			if rr.MaxItems != nil {
+				schema["maxItems"] = rr.GetMaxItems()
+			}
+			if rr.GetUnique() {
+				schema["uniqueItems"] = true
+			}
+		}
+	}
+	if bv := bufValidateConstraints(fd); bv != nil {
+		if rr := bv.GetRepeated(); rr != nil {
+			if rr.MinItems != nil {
+				schema["minItems"] = rr.GetMinItems()
+			}
+			if rr.MaxItems != nil {
+				schema["maxItems"] = rr.GetMaxItems()
+			}
+			if rr.GetUnique() {
+				schema["uniqueItems"] = true
+			}
+		}
+	}
+}
+
+// messageSchema builds the JSON Schema for msg. forInput is true when msg is
+// being used as a request body (an MCP tool's input, or an OpenAPI
+// requestBody): OUTPUT_ONLY fields are server-populated and dropped from
+// those schemas entirely. forInput is false for a response/output schema,
+// where OUTPUT_ONLY fields are exactly the ones that matter.
+func (g *fileGenerator) messageSchema(msg *protogen.Message, forInput bool) map[string]any {
+	md := msg.Desc
 	required := []string{}
 	// Fields that are not oneOf
 	normalFields := map[string]any{}
 	// One entry per oneOf block in the message.
 	oneOf := map[string][]map[string]any{}
 
+	disabled := messageValidationDisabled(md)
+
 	// Process all fields in the message descriptor
-	for i := 0; i < md.Fields().Len(); i++ {
-		nestedFd := md.Fields().Get(i)
+	for _, nestedField := range msg.Fields {
+		nestedFd := nestedField.Desc
+		if forInput && hasFieldBehavior(nestedFd, annotations.FieldBehavior_OUTPUT_ONLY) {
+			// Server-populated; including it in the input schema only confuses callers.
+			continue
+		}
 		name := string(nestedFd.Name())
+		if !g.useProtoNames {
+			name = nestedFd.JSONName()
+		}
 
 		// Check if the field is part of a oneof group
 		if oneof := nestedFd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
@@ -136,14 +726,14 @@ func messageSchema(md protoreflect.MessageDescriptor) map[string]any {
 			}
 			oneOf[string(oneof.Name())] = append(oneOf[string(oneof.Name())], map[string]any{
 				"properties": map[string]any{
-					name: getType(nestedFd),
+					name: g.getType(nestedField, disabled, forInput),
 				},
 				"required": []string{name},
 			})
 		} else {
 			// If not part of a oneof, handle as a normal field
-			normalFields[name] = getType(nestedFd)
-			if isFieldRequired(nestedFd) {
+			normalFields[name] = g.getType(nestedField, disabled, forInput)
+			if isFieldRequired(nestedFd, disabled) {
 				required = append(required, name)
 			}
 		}
@@ -169,10 +759,53 @@ func messageSchema(md protoreflect.MessageDescriptor) map[string]any {
 	if anyOf != nil {
 		result["anyOf"] = anyOf // Fields in properties are already allowed. anyOf is in addition - which covers all oneOf groups
 	}
+	if g.includeDescriptions {
+		if desc := cleanComment(string(msg.Comments.Leading)); desc != "" {
+			result["description"] = desc
+		}
+	}
+	if !disabled {
+		if exprs := messageCELExpressions(md); len(exprs) > 0 {
+			result["$comment"] = "CEL rules: " + strings.Join(exprs, "; ")
+		}
+	}
 	return result
 }
 
-func getType(fd protoreflect.FieldDescriptor) map[string]any {
+// getType builds the JSON Schema fragment for a single field, including any
+// protoc-gen-validate / buf.validate constraints unless constraintsDisabled
+// (set when the containing message carries a validate.disabled option), plus
+// any google.api.field_behavior IMMUTABLE/INPUT_ONLY annotation and the
+// field's leading/trailing comment as a "description".
+func (g *fileGenerator) getType(field *protogen.Field, constraintsDisabled, forInput bool) map[string]any {
+	schema := g.baseType(field, constraintsDisabled, forInput)
+	applyFieldBehaviorAnnotations(field.Desc, schema)
+	if g.includeDescriptions {
+		if desc := fieldDescription(field); desc != "" {
+			schema["description"] = desc
+		}
+	}
+	return schema
+}
+
+// fieldDescription joins a field's leading and trailing comments, reusing the
+// same cleanComment pass applied to mcp.Tool.Description, so LLMs see the
+// documentation authors already wrote for human readers.
+func fieldDescription(field *protogen.Field) string {
+	leading := cleanComment(string(field.Comments.Leading))
+	trailing := cleanComment(string(field.Comments.Trailing))
+	switch {
+	case leading != "" && trailing != "":
+		return leading + "\n" + trailing
+	case leading != "":
+		return leading
+	default:
+		return trailing
+	}
+}
+
+func (g *fileGenerator) baseType(field *protogen.Field, constraintsDisabled, forInput bool) map[string]any {
+	fd := field.Desc
 	var schema map[string]any
 	if fd.IsMap() {
 		// Add key constraints. Map keys in protobuf can have different primitive types, where JSON can use only string.
@@ -191,10 +824,12 @@ func getType(fd protoreflect.FieldDescriptor) map[string]any {
 			keyConstraints["pattern"] = "^-?(0|[1-9]\\d*)$" // signed integers, no leading zeros
 		default:
 		}
+		// The map entry's "value" field (index 1; index 0 is "key") carries
+		// the real element type, including its Message/Enum for recursion.
 		return map[string]any{
 			"type":                 "object",
 			"propertyNames":        keyConstraints,
-			"additionalProperties": getType(fd.MapValue()),
+			"additionalProperties": g.getType(field.Message.Fields[1], constraintsDisabled, forInput),
 		}
 	} else if fd.Kind() == protoreflect.MessageKind {
 		if fd.Kind() == protoreflect.MessageKind {
@@ -267,18 +902,29 @@ func getType(fd protoreflect.FieldDescriptor) map[string]any {
 				}
 			}
 		}
-		return messageSchema(fd.Message())
+		return g.messageSchema(field.Message, forInput)
 	} else if fd.Kind() == protoreflect.EnumKind {
 		var values []string
+		var descriptions []string
+		hasDescriptions := false
 
-		for i := 0; i < fd.Enum().Values().Len(); i++ {
-			ev := fd.Enum().Values().Get(i)
-			values = append(values, string(ev.Name()))
+		for _, ev := range field.Enum.Values {
+			values = append(values, string(ev.Desc.Name()))
+			var desc string
+			if g.includeDescriptions {
+				desc = cleanComment(string(ev.Comments.Leading))
+			}
+			hasDescriptions = hasDescriptions || desc != ""
+			descriptions = append(descriptions, desc)
 		}
-		return map[string]any{
+		enumSchema := map[string]any{
 			"type": "string",
 			"enum": values,
 		}
+		if hasDescriptions {
+			enumSchema["enumDescriptions"] = descriptions
+		}
+		return enumSchema
 	} else {
 		schema = map[string]any{
 			"type": kindToType(fd.Kind()),
@@ -290,12 +936,27 @@ func getType(fd protoreflect.FieldDescriptor) map[string]any {
 		schema["format"] = "byte"
 	}
 
+	if !constraintsDisabled && !fd.IsList() {
+		pgv := pgvRules(fd)
+		if !pgvFieldIgnoresEmpty(pgv) {
+			applyPGVFieldConstraints(pgv, schema)
+		}
+		bv := bufValidateConstraints(fd)
+		if !bufValidateIgnored(bv) {
+			applyBufValidateFieldConstraints(bv, schema)
+		}
+	}
+
 	// If array, wrap it with array type (and put actual schema into "items"
 	if fd.IsList() {
-		return map[string]any{
+		arraySchema := map[string]any{
 			"type":  "array",
 			"items": schema,
 		}
+		if !constraintsDisabled {
+			applyRepeatedConstraints(fd, arraySchema)
+		}
+		return arraySchema
 	}
 	return schema
 }
@@ -342,6 +1003,141 @@ func MangleHeadIfTooLong(name string, maxLen int) string {
 	return hashPrefix + "_" + tail
 }
 
+// arrayWrappedSchema wraps a per-message schema as the "items" property of an
+// object schema, for a pure client-streaming tool's input: MCP tool
+// arguments always arrive as a JSON object (mcp.CallToolRequest.GetArguments()
+// is a map), so the stream of messages to send can't be a bare top-level
+// array the way a naive "one array, one message per element" schema would
+// suggest. Compare sessionWrappedSchema below for the analogous
+// bidi-streaming wrapping.
+func arrayWrappedSchema(item map[string]any) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"items": map[string]any{
+				"type":  "array",
+				"items": item,
+			},
+		},
+		"required": []string{"items"},
+	}
+}
+
+// sessionWrappedSchema wraps a message schema with the session_id every
+// bidi-streaming "_send"/"_recv" tool needs to identify which in-flight
+// stream it's talking to. payload is omitted for "_recv", which only needs
+// the session id to pull the next message off the stream.
+func sessionWrappedSchema(payload map[string]any) map[string]any {
+	properties := map[string]any{
+		"session_id": map[string]any{
+			"type":        "string",
+			"description": "Identifies the bidi-streaming session created by the first call to this method's tool.",
+		},
+	}
+	required := []string{"session_id"}
+	if payload != nil {
+		properties["message"] = payload
+		required = append(required, "message")
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// bidiStreamingTool builds the "_send" or "_recv" half of the pair of tools
+// generated for a bidi-streaming method under --streaming=collect or
+// --streaming=subscribe.
+func (g *fileGenerator) bidiStreamingTool(meth *protogen.Method, methodName, role string) Tool {
+	var schema map[string]any
+	switch role {
+	case "send":
+		schema = sessionWrappedSchema(g.messageSchema(meth.Input, true))
+	case "recv":
+		schema = sessionWrappedSchema(nil)
+	}
+	marshaled, err := json.Marshal(schema)
+	if err != nil {
+		panic(err)
+	}
+
+	tool := mcp.Tool{
+		Name:        MangleHeadIfTooLong(methodName+"_"+role, 64),
+		Description: cleanComment(string(meth.Comments.Leading)),
+	}
+	tool.RawInputSchema = json.RawMessage(marshaled)
+
+	return Tool{
+		RequestType:  g.gf.QualifiedGoIdent(meth.Input.GoIdent),
+		ResponseType: g.gf.QualifiedGoIdent(meth.Output.GoIdent),
+		MCPTool:      tool,
+		Streaming: &StreamingBinding{
+			Mode:            g.streaming.mode,
+			ClientStreaming: true,
+			ServerStreaming: true,
+			Role:            role,
+		},
+	}
+}
+
+// goIdentSuffix turns the base component of a generated-filename prefix (e.g.
+// "some/path/foo" -> "foo") into a valid, exported-safe Go identifier
+// fragment, so it can be appended to the dispatch helpers gen.tmpl emits once
+// per file.
+func goIdentSuffix(base string) string {
+	var b strings.Builder
+	for _, r := range base {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	out := b.String()
+	if out == "" || (out[0] >= '0' && out[0] <= '9') {
+		out = "_" + out
+	}
+	return out
+}
+
+// templateFuncs are the helpers gen.tmpl uses to render Go source literals
+// from the generator-time data in tplParams/Tool: text/template has no
+// builtin for quoting a Go string or composing a slice/map literal, so the
+// template calls back into Go for that instead of hand-rolling escaping.
+var templateFuncs = template.FuncMap{
+	"goQuote": func(s string) string { return strconv.Quote(s) },
+	"rawJSON": func(raw json.RawMessage) string { return strconv.Quote(string(raw)) },
+	"goStringSlice": func(items []string) string {
+		quoted := make([]string, len(items))
+		for i, s := range items {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "[]string{" + strings.Join(quoted, ", ") + "}"
+	},
+	"goStringMap": func(m map[string]string) string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("map[string]string{")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s: %s, ", strconv.Quote(k), strconv.Quote(m[k]))
+		}
+		b.WriteString("}")
+		return b.String()
+	},
+	// methodBase strips the "_Send"/"_Recv" suffix Generate adds to a
+	// bidi method's Services map key, recovering the Go method name needed to
+	// start the underlying stream (client.<MethodBase>(ctx)).
+	"methodBase": func(s string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(s, "_Send"), "_Recv")
+	},
+}
+
 func (g *fileGenerator) Generate() {
 	file := g.f
 	if len(g.f.Services) == 0 {
@@ -354,7 +1150,7 @@ func (g *fileGenerator) Generate() {
 		goImportPath,
 	)
 	fileTpl := fileTemplate
-	tpl, err := template.New("gen").Parse(fileTpl)
+	tpl, err := template.New("gen").Funcs(templateFuncs).Parse(fileTpl)
 	if err != nil {
 		g.gen.Error(err)
 		return
@@ -366,45 +1162,220 @@ func (g *fileGenerator) Generate() {
 	for _, svc := range g.f.Services {
 		s := map[string]Tool{}
 		for _, meth := range svc.Methods {
-			// Only unary supported at the moment
-			if meth.Desc.IsStreamingClient() || meth.Desc.IsStreamingServer() {
+			clientStreaming := meth.Desc.IsStreamingClient()
+			serverStreaming := meth.Desc.IsStreamingServer()
+			if (clientStreaming || serverStreaming) && g.streaming.mode == streamingSkip {
 				continue
 			}
+
 			methodName := string(meth.Desc.FullName())
 			if nameSplit := strings.Split(string(meth.Desc.FullName()), "."); len(nameSplit) >= 2 {
 				methodName = strings.Join(nameSplit[len(nameSplit)-2:], "_")
 			}
+
+			if clientStreaming && serverStreaming {
+				// google.api.http only transcodes unary and one-directional-streaming
+				// methods, so --dispatch=http can never produce a working bidi tool;
+				// apply the same hard error other methods get below instead of
+				// silently emitting a tool with no HTTP mapping.
+				if g.dispatch.mode == dispatchHTTP {
+					g.gen.Error(fmt.Errorf("protoc-gen-go-mcp: %s is a bidi-streaming method, which --dispatch=http cannot support", methodName))
+					continue
+				}
+				sendTool := g.bidiStreamingTool(meth, methodName, "send")
+				recvTool := g.bidiStreamingTool(meth, methodName, "recv")
+				s[meth.GoName+"_Send"] = sendTool
+				s[meth.GoName+"_Recv"] = recvTool
+				tools[svc.GoName+"_"+meth.GoName+"_Send"] = sendTool.MCPTool
+				tools[svc.GoName+"_"+meth.GoName+"_Recv"] = recvTool.MCPTool
+				continue
+			}
+
 			tool := mcp.Tool{
 				Name:        MangleHeadIfTooLong(methodName, 64),
 				Description: cleanComment(string(meth.Comments.Leading)),
 			}
 
-			m := messageSchema(meth.Input.Desc)
+			m := g.messageSchema(meth.Input, true)
+			if clientStreaming && g.dispatch.mode != dispatchHTTP {
+				// A client-streaming tool call carries the whole stream up front, one
+				// message per "items" element. Under --dispatch=http the tool sends
+				// a single request message, so its schema stays unwrapped.
+				m = arrayWrappedSchema(m)
+			}
 			marshaled, err := json.Marshal(m)
 			if err != nil {
 				panic(err)
 			}
 			tool.RawInputSchema = json.RawMessage(marshaled)
 
+			var http *HTTPBinding
+			if verb, path, body, ok := httpRule(meth); ok {
+				baseURL := g.dispatch.baseURL
+				if override, ok := g.dispatch.baseURLOverrides[methodName]; ok {
+					baseURL = override
+				}
+				http = &HTTPBinding{
+					Verb:         verb,
+					PathTemplate: path,
+					PathParams:   pathParams(path),
+					Body:         body,
+					BaseURL:      baseURL,
+					Headers:      mergeHTTPHeaders(g.dispatch.headers, g.dispatch.headerOverrides[methodName]),
+				}
+			}
+			if g.dispatch.mode == dispatchHTTP && http == nil {
+				g.gen.Error(fmt.Errorf("protoc-gen-go-mcp: %s has no google.api.http option but --dispatch=http was requested", methodName))
+				continue
+			}
+
+			var stream *StreamingBinding
+			if clientStreaming || serverStreaming {
+				stream = &StreamingBinding{
+					Mode:            g.streaming.mode,
+					ClientStreaming: clientStreaming,
+					ServerStreaming: serverStreaming,
+					MaxItems:        g.streaming.maxItems,
+					Timeout:         g.streaming.timeout,
+				}
+			}
+
 			s[meth.GoName] = Tool{
 				RequestType:  g.gf.QualifiedGoIdent(meth.Input.GoIdent),
 				ResponseType: g.gf.QualifiedGoIdent(meth.Output.GoIdent),
 				MCPTool:      tool,
+				HTTP:         http,
+				Streaming:    stream,
 			}
 			tools[svc.GoName+"_"+meth.GoName] = tool
 		}
 		services[string(svc.Desc.Name())] = s
 	}
 
+	needsCollectHelpers := false
+	for _, svcTools := range services {
+		for _, t := range svcTools {
+			if g.dispatch.mode == dispatchGRPC && t.Streaming != nil && t.Streaming.ServerStreaming && !t.Streaming.ClientStreaming &&
+				t.Streaming.Role == "" && t.Streaming.Mode == streamingCollect {
+				needsCollectHelpers = true
+			}
+		}
+	}
+
+	fileBase := file.GeneratedFilenamePrefix
+	if idx := strings.LastIndex(fileBase, "/"); idx >= 0 {
+		fileBase = fileBase[idx+1:]
+	}
+
 	params := tplParams{
-		PackageName: string(g.f.Desc.Package()),
-		SourcePath:  g.f.Desc.Path(),
-		GoPackage:   string(g.f.GoPackageName),
-		Services:    services,
-		Tools:       tools,
+		PackageName:         string(g.f.Desc.Package()),
+		SourcePath:          g.f.Desc.Path(),
+		GoPackage:           string(g.f.GoPackageName),
+		Services:            services,
+		Tools:               tools,
+		Dispatch:            g.dispatch.mode,
+		Streaming:           g.streaming.mode,
+		NeedsCollectHelpers: needsCollectHelpers,
+		FileSuffix:          goIdentSuffix(fileBase),
 	}
 	err = tpl.Execute(g.gf, params)
 	if err != nil {
 		g.gen.Error(err)
+		return
+	}
+
+	if g.emitOpenAPI {
+		g.generateOpenAPI()
+	}
+}
+
+// generateOpenAPI writes a companion <prefix>.openapi.json document next to
+// the .pb.mcp.go file, reusing messageSchema so a field's JSON Schema (and
+// its required/enum/oneOf shape) stays identical across both outputs.
+func (g *fileGenerator) generateOpenAPI() {
+	doc := g.openAPIDocument()
+	marshaled, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		g.gen.Error(err)
+		return
+	}
+	openapiFile := g.gen.NewGeneratedFile(g.f.GeneratedFilenamePrefix+openAPIFilenameExtension, "")
+	if _, err := openapiFile.Write(marshaled); err != nil {
+		g.gen.Error(err)
+	}
+}
+
+// openAPISchemas accumulates message schemas keyed by full proto name so a
+// message referenced from multiple request/response bodies is only emitted
+// once under #/components/schemas.
+type openAPISchemas map[string]map[string]any
+
+// ref returns a "$ref" pointer into components.schemas for msg, building and
+// caching its schema on first use. forInput must match messageSchema's
+// semantics: the same message type used as both a request and a response
+// body gets two distinct component entries, since an input schema drops
+// OUTPUT_ONLY fields that an output schema must keep.
+func (g *fileGenerator) ref(schemas openAPISchemas, msg *protogen.Message, forInput bool) map[string]any {
+	key := string(msg.Desc.FullName())
+	if forInput {
+		key += "Input"
+	}
+	if _, ok := schemas[key]; !ok {
+		schemas[key] = g.messageSchema(msg, forInput)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + key}
+}
+
+func (g *fileGenerator) openAPIDocument() map[string]any {
+	schemas := openAPISchemas{}
+	paths := map[string]any{}
+
+	for _, svc := range g.f.Services {
+		for _, meth := range svc.Methods {
+			if meth.Desc.IsStreamingClient() || meth.Desc.IsStreamingServer() {
+				// OpenAPI 3.1 has no native notion of a gRPC stream; streaming methods are only exposed via MCP.
+				continue
+			}
+			operation := map[string]any{
+				"operationId": svc.GoName + "_" + meth.GoName,
+				"requestBody": map[string]any{
+					"required": true,
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": g.ref(schemas, meth.Input, true),
+						},
+					},
+				},
+				"responses": map[string]any{
+					"200": map[string]any{
+						"description": "OK",
+						"content": map[string]any{
+							"application/json": map[string]any{
+								"schema": g.ref(schemas, meth.Output, false),
+							},
+						},
+					},
+				},
+			}
+			if summary := cleanComment(string(meth.Comments.Leading)); summary != "" {
+				operation["summary"] = summary
+			}
+			paths[fmt.Sprintf("/%s/%s", svc.Desc.Name(), meth.Desc.Name())] = map[string]any{
+				"post": operation,
+			}
+		}
+	}
+
+	doc := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   string(g.f.Desc.Package()),
+			"version": "0.0.0",
+		},
+		"paths": paths,
+	}
+	if len(schemas) > 0 {
+		doc["components"] = map[string]any{"schemas": map[string]map[string]any(schemas)}
 	}
+	return doc
 }